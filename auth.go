@@ -0,0 +1,122 @@
+package sunrise
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Authentication schemes the sunrise HTTP endpoint may require.
+const (
+	AuthTypeBearer = "bearer"
+	AuthTypeBasic  = "basic"
+	AuthTypeAPIKey = "apikey"
+)
+
+const defaultTokenTTL = 5 * time.Minute
+
+// TokenProviderFunc fetches a short-lived credential on demand, e.g. from
+// Vault or an OIDC provider. It takes priority over AuthConfig.Token when
+// set, and is cached with an expiry between calls.
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// AuthConfig describes how to authenticate requests to the sunrise node.
+type AuthConfig struct {
+	// Type selects the auth scheme: AuthTypeBearer, AuthTypeBasic, or
+	// AuthTypeAPIKey. Leave unset to send unauthenticated requests.
+	Type     string `json:"type"`
+	Token    string `json:"token"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// HeaderName overrides the header Token is attached to under
+	// AuthTypeAPIKey. Defaults to "X-API-Key".
+	HeaderName string `json:"header_name"`
+	// TokenTTL controls how long a TokenProvider-sourced token is cached
+	// before being refetched. Defaults to 5 minutes.
+	TokenTTL time.Duration `json:"token_ttl"`
+	// TokenProvider, when set, is called to fetch Token instead of using
+	// the static field above. It cannot be populated from JSON config
+	// files.
+	TokenProvider TokenProviderFunc `json:"-"`
+}
+
+// authenticator injects credentials into outbound requests and caches
+// TokenProvider-sourced tokens, refreshing them on demand (e.g. after a
+// 401) rather than on every call.
+type authenticator struct {
+	config AuthConfig
+
+	mu          sync.Mutex
+	cachedToken string
+	expires     time.Time
+}
+
+func newAuthenticator(config AuthConfig) *authenticator {
+	return &authenticator{config: config}
+}
+
+// apply attaches credentials to req, forcing a TokenProvider refresh first
+// when forceRefresh is set (used to retry once after a 401).
+func (a *authenticator) apply(ctx context.Context, req *http.Request, forceRefresh bool) error {
+	switch a.config.Type {
+	case "":
+		return nil
+	case AuthTypeBearer:
+		token, err := a.token(ctx, forceRefresh)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case AuthTypeAPIKey:
+		token, err := a.token(ctx, forceRefresh)
+		if err != nil {
+			return err
+		}
+		headerName := a.config.HeaderName
+		if headerName == "" {
+			headerName = "X-API-Key"
+		}
+		req.Header.Set(headerName, token)
+	case AuthTypeBasic:
+		req.SetBasicAuth(a.config.Username, a.config.Password)
+	default:
+		return fmt.Errorf("sunrise: unknown auth type %q", a.config.Type)
+	}
+	return nil
+}
+
+func (a *authenticator) token(ctx context.Context, forceRefresh bool) (string, error) {
+	if a.config.TokenProvider == nil {
+		return a.config.Token, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !forceRefresh && a.cachedToken != "" && time.Now().Before(a.expires) {
+		return a.cachedToken, nil
+	}
+
+	token, err := a.config.TokenProvider(ctx)
+	if err != nil {
+		return "", fmt.Errorf("sunrise: fetching auth token: %w", err)
+	}
+
+	ttl := a.config.TokenTTL
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	a.cachedToken = token
+	a.expires = time.Now().Add(ttl)
+
+	return token, nil
+}
+
+// refreshable reports whether a 401 response is worth retrying once with a
+// forced token refresh, i.e. credentials come from a TokenProvider.
+func (a *authenticator) refreshable() bool {
+	return a.config.TokenProvider != nil
+}
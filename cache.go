@@ -0,0 +1,113 @@
+package sunrise
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/rollkit/go-da"
+)
+
+type cacheEntry struct {
+	blob      da.Blob
+	expiresAt time.Time
+}
+
+// blobCache is an optional, size-bounded LRU cache of Get results keyed by
+// metadata URI. A nil *blobCache is a valid, always-empty cache so callers
+// don't need to guard every use behind a CacheSize check.
+type blobCache struct {
+	lru *lru.Cache[string, cacheEntry]
+	ttl time.Duration
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// newBlobCache returns nil, disabling caching, when size is not positive.
+func newBlobCache(size int, ttl time.Duration) (*blobCache, error) {
+	if size <= 0 {
+		return nil, nil
+	}
+
+	l, err := lru.New[string, cacheEntry](size)
+	if err != nil {
+		return nil, fmt.Errorf("constructing blob cache: %w", err)
+	}
+
+	return &blobCache{lru: l, ttl: ttl}, nil
+}
+
+func (c *blobCache) get(key string) (da.Blob, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	entry, ok := c.lru.Get(key)
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.lru.Remove(key)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	return entry.blob, true
+}
+
+func (c *blobCache) set(key string, blob da.Blob) {
+	if c == nil {
+		return
+	}
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	c.lru.Add(key, cacheEntry{blob: blob, expiresAt: expiresAt})
+}
+
+// cacheControlNoStore reports whether a Cache-Control header value carries
+// a no-store directive, per RFC 9111 a comma-separated, case-insensitive
+// list rather than a single exact token.
+func cacheControlNoStore(value string) bool {
+	for _, directive := range strings.Split(value, ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *blobCache) purge(key string) {
+	if c == nil {
+		return
+	}
+	c.lru.Remove(key)
+}
+
+// CacheStats reports SunriseDA's Get cache hit rate.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Len    int
+}
+
+func (c *blobCache) stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Len:    c.lru.Len(),
+	}
+}
@@ -0,0 +1,88 @@
+package sunrise
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rollkit/go-da"
+)
+
+func TestCacheControlNoStore(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"exact", "no-store", true},
+		{"mixed case", "No-Store", true},
+		{"multi directive trailing", "no-store, max-age=0", true},
+		{"multi directive leading", "max-age=0, no-store", true},
+		{"unrelated directive", "max-age=60", false},
+		{"empty", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cacheControlNoStore(tc.value); got != tc.want {
+				t.Fatalf("cacheControlNoStore(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBlobCacheGetSetPurgeStats(t *testing.T) {
+	cache, err := newBlobCache(2, 0)
+	if err != nil {
+		t.Fatalf("newBlobCache returned error: %v", err)
+	}
+
+	if _, ok := cache.get("missing"); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	cache.set("a", da.Blob("blob-a"))
+	blob, ok := cache.get("a")
+	if !ok || string(blob) != "blob-a" {
+		t.Fatalf("expected hit with blob-a, got %q ok=%v", blob, ok)
+	}
+
+	cache.purge("a")
+	if _, ok := cache.get("a"); ok {
+		t.Fatalf("expected miss after purge")
+	}
+
+	stats := cache.stats()
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestBlobCacheTTLExpiry(t *testing.T) {
+	cache, err := newBlobCache(2, time.Millisecond)
+	if err != nil {
+		t.Fatalf("newBlobCache returned error: %v", err)
+	}
+
+	cache.set("a", da.Blob("blob-a"))
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := cache.get("a"); ok {
+		t.Fatalf("expected entry to have expired after its TTL")
+	}
+}
+
+func TestNewBlobCacheDisabled(t *testing.T) {
+	cache, err := newBlobCache(0, 0)
+	if err != nil {
+		t.Fatalf("newBlobCache returned error: %v", err)
+	}
+	if cache != nil {
+		t.Fatalf("expected nil cache when size is non-positive")
+	}
+
+	// A nil *blobCache must still be safe to use as a no-op cache.
+	cache.set("a", da.Blob("x"))
+	if _, ok := cache.get("a"); ok {
+		t.Fatalf("expected miss from a disabled cache")
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"log"
@@ -26,8 +25,10 @@ func main() {
 	if err != nil {
 		log.Fatalln("Error parsing config file:", err)
 	}
-	ctx := context.Background()
-	da := sunrise.NewSunriseDA(ctx, config)
+	da, err := sunrise.NewSunriseDA(config)
+	if err != nil {
+		log.Fatalln("Error constructing sunrise DA client:", err)
+	}
 	srv := proxy.NewServer(da, grpc.Creds(insecure.NewCredentials()))
 	lis, err := net.Listen("tcp", config.GRPCServerAddress)
 	if err != nil {
@@ -0,0 +1,155 @@
+package sunrise
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defaultMaxRetries        = 5
+	defaultInitialBackoff    = 5 * time.Second
+	defaultMaxBackoff        = time.Minute
+	defaultBackoffMultiplier = 2.0
+	defaultBackoffJitter     = 0.5
+	defaultMaxElapsedTime    = 5 * time.Minute
+)
+
+func durationOrDefault(v, def time.Duration) time.Duration {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+func floatOrDefault(v, def float64) float64 {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+// newBackoff builds the exponential backoff policy used to retry transient
+// sunrise HTTP failures, seeded from config with the package defaults
+// (initial 5s, multiplier 2, max interval 1m, max elapsed 5m, jitter 0.5)
+// filling in anything left unset.
+func newBackoff(config Config) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = durationOrDefault(config.InitialBackoff, defaultInitialBackoff)
+	b.MaxInterval = durationOrDefault(config.MaxBackoff, defaultMaxBackoff)
+	b.Multiplier = floatOrDefault(config.BackoffMultiplier, defaultBackoffMultiplier)
+	b.RandomizationFactor = floatOrDefault(config.BackoffJitter, defaultBackoffJitter)
+	b.MaxElapsedTime = defaultMaxElapsedTime
+
+	maxRetries := config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	return backoff.WithMaxRetries(b, uint64(maxRetries))
+}
+
+// doOnce issues a single method/url request with body (nil for requests
+// with no body), attaching auth credentials, and returns the response body
+// alongside its status code. forceAuthRefresh forces a TokenProvider
+// refresh before attaching credentials, used to retry once after a 401.
+func (sunrise *SunriseDA) doOnce(ctx context.Context, method, url string, body []byte, contentType string, forceAuthRefresh bool) ([]byte, int, http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if sunrise.auth != nil {
+		if err := sunrise.auth.apply(ctx, req, forceAuthRefresh); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	response, err := sunrise.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Println("error closing response body", err)
+		}
+	}()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return data, response.StatusCode, response.Header, nil
+}
+
+// doRequestWithRetry issues method/url with body (nil for requests with no
+// body) and retries transient failures with exponential backoff: network
+// errors, 5xx responses, and timeouts where ctx has not yet expired. 4xx
+// responses are treated as permanent, since they indicate a malformed
+// request rather than a node hiccup, except a 401 caused by a stale
+// TokenProvider-sourced credential, which is retried once with a forced
+// refresh. It returns the response body and headers on a successful (2xx)
+// response.
+func (sunrise *SunriseDA) doRequestWithRetry(ctx context.Context, method, url string, body []byte, contentType string) ([]byte, http.Header, error) {
+	var responseData []byte
+	var responseHeader http.Header
+	attempt := 0
+
+	operation := func() error {
+		data, status, header, err := sunrise.doOnce(ctx, method, url, body, contentType, false)
+		if err != nil {
+			if ctx.Err() != nil {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+
+		if status == http.StatusUnauthorized && sunrise.auth != nil && sunrise.auth.refreshable() {
+			data, status, header, err = sunrise.doOnce(ctx, method, url, body, contentType, true)
+			if err != nil {
+				if ctx.Err() != nil {
+					return backoff.Permanent(err)
+				}
+				return err
+			}
+		}
+
+		switch {
+		case status >= 500:
+			return fmt.Errorf("sunrise node returned status %d: %s", status, data)
+		case status >= 400:
+			return backoff.Permanent(fmt.Errorf("sunrise node returned status %d: %s", status, data))
+		case status >= 300:
+			return backoff.Permanent(fmt.Errorf("sunrise node returned unexpected status %d: %s", status, data))
+		}
+
+		responseData = data
+		responseHeader = header
+		return nil
+	}
+
+	notify := func(err error, wait time.Duration) {
+		attempt++
+		log.Printf("sunrise: retrying %s %s (attempt %d) after %s: %v", method, url, attempt, wait, err)
+	}
+
+	if err := backoff.RetryNotify(operation, backoff.WithContext(newBackoff(sunrise.config), ctx), notify); err != nil {
+		return nil, nil, err
+	}
+
+	return responseData, responseHeader, nil
+}
@@ -0,0 +1,88 @@
+package sunrise
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+func TestDurationOrDefault(t *testing.T) {
+	cases := []struct {
+		name   string
+		v, def time.Duration
+		want   time.Duration
+	}{
+		{"zero uses default", 0, time.Second, time.Second},
+		{"positive value kept", 2 * time.Second, time.Second, 2 * time.Second},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := durationOrDefault(tc.v, tc.def); got != tc.want {
+				t.Fatalf("durationOrDefault(%v, %v) = %v, want %v", tc.v, tc.def, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFloatOrDefault(t *testing.T) {
+	cases := []struct {
+		name   string
+		v, def float64
+		want   float64
+	}{
+		{"zero uses default", 0, 0.5, 0.5},
+		{"positive value kept", 2, 0.5, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := floatOrDefault(tc.v, tc.def); got != tc.want {
+				t.Fatalf("floatOrDefault(%v, %v) = %v, want %v", tc.v, tc.def, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewBackoffRespectsMaxRetries(t *testing.T) {
+	config := Config{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+
+	attempts := 0
+	err := backoff.Retry(func() error {
+		attempts++
+		return errors.New("boom")
+	}, newBackoff(config))
+
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (1 initial + MaxRetries=2), got %d", attempts)
+	}
+}
+
+func TestNewBackoffDefaultsMaxRetries(t *testing.T) {
+	config := Config{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+
+	attempts := 0
+	err := backoff.Retry(func() error {
+		attempts++
+		return errors.New("boom")
+	}, newBackoff(config))
+
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if attempts != defaultMaxRetries+1 {
+		t.Fatalf("expected %d attempts (1 initial + default MaxRetries=%d), got %d", defaultMaxRetries+1, defaultMaxRetries, attempts)
+	}
+}
@@ -1,18 +1,22 @@
 package sunrise
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
+	"net"
 	"net/http"
-	"sync"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 
 	"github.com/rollkit/go-da"
+
+	"github.com/sunriselayer/sunrise-rollkit/verify"
 )
 
 type PublishRequest struct {
@@ -31,23 +35,145 @@ type GetBlobResponse struct {
 	Blob string `json:"blob"`
 }
 
+// Storage protocols a sunrise node may be configured to publish blobs to.
+const (
+	ProtocolIPFS     = "ipfs"
+	ProtocolArweave  = "arweave"
+	ProtocolFilecoin = "filecoin"
+	ProtocolLocal    = "local"
+)
+
+var supportedProtocols = map[string]struct{}{
+	ProtocolIPFS:     {},
+	ProtocolArweave:  {},
+	ProtocolFilecoin: {},
+	ProtocolLocal:    {},
+}
+
+// ErrUnsupportedProtocol is returned when a caller requests a storage
+// protocol the client doesn't recognize, rather than letting the sunrise
+// node mis-route the publish request.
+type ErrUnsupportedProtocol struct {
+	Protocol string
+}
+
+func (e *ErrUnsupportedProtocol) Error() string {
+	return fmt.Sprintf("sunrise: unsupported storage protocol %q", e.Protocol)
+}
+
+func validateProtocol(protocol string) error {
+	if _, ok := supportedProtocols[protocol]; !ok {
+		return &ErrUnsupportedProtocol{Protocol: protocol}
+	}
+	return nil
+}
+
+// SubmitOptions overrides per-call Submit behavior.
+type SubmitOptions struct {
+	// Protocol, when set, overrides Config.Protocol for this call.
+	Protocol string
+}
+
+// TransportConfig tunes the *http.Client built for a SunriseDA when
+// Config.HTTPClient is not set explicitly.
+type TransportConfig struct {
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"`
+	KeepAlive           time.Duration `json:"keep_alive"`
+}
+
 type Config struct {
 	ServerURL         string `json:"server_url"`
 	DataShardCount    uint32 `json:"data_shard_count"`
 	ParityShardCount  uint32 `json:"parity_shard_count"`
 	GRPCServerAddress string `json:"grpc_server_address"`
+	// Protocol is the default storage backend blobs are published to, e.g.
+	// ProtocolIPFS. Defaults to ProtocolIPFS if unset.
+	Protocol      string          `json:"protocol"`
+	Verify        verify.Config   `json:"verify"`
+	SubmitTimeout time.Duration   `json:"submit_timeout"`
+	GetTimeout    time.Duration   `json:"get_timeout"`
+	Transport     TransportConfig `json:"transport"`
+	// MaxRetries, InitialBackoff, MaxBackoff, BackoffMultiplier and
+	// BackoffJitter configure the exponential-backoff retry applied to
+	// publish/get HTTP calls. Zero values fall back to package defaults.
+	MaxRetries        int           `json:"max_retries"`
+	InitialBackoff    time.Duration `json:"initial_backoff"`
+	MaxBackoff        time.Duration `json:"max_backoff"`
+	BackoffMultiplier float64       `json:"backoff_multiplier"`
+	BackoffJitter     float64       `json:"backoff_jitter"`
+	// Auth configures credentials attached to every request against the
+	// sunrise node. Leave zero-valued to send unauthenticated requests.
+	Auth AuthConfig `json:"auth"`
+	// CacheSize bounds the number of decoded Get results kept in an
+	// in-process LRU cache keyed by metadata URI. Zero disables caching.
+	CacheSize int `json:"cache_size"`
+	// CacheTTL expires cached entries after the given duration. Zero means
+	// entries are kept until evicted by CacheSize.
+	CacheTTL time.Duration `json:"cache_ttl"`
+	// HTTPClient, when set, is used as-is instead of building one from
+	// Transport. It cannot be populated from JSON config files.
+	HTTPClient *http.Client `json:"-"`
 }
 
 type SunriseDA struct {
-	ctx    context.Context
-	config Config
+	config     Config
+	verifier   *verify.Verifier
+	httpClient *http.Client
+	auth       *authenticator
+	cache      *blobCache
 }
 
-func NewSunriseDA(ctx context.Context, config Config) *SunriseDA {
-	return &SunriseDA{
-		ctx:    ctx,
-		config: config,
+func NewSunriseDA(config Config) (*SunriseDA, error) {
+	if config.Protocol == "" {
+		config.Protocol = ProtocolIPFS
+	}
+	if err := validateProtocol(config.Protocol); err != nil {
+		return nil, err
+	}
+
+	verifier, err := verify.NewVerifier(config.Verify)
+	if err != nil {
+		return nil, fmt.Errorf("constructing verifier: %w", err)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if config.Transport.MaxIdleConns > 0 {
+			transport.MaxIdleConns = config.Transport.MaxIdleConns
+		}
+		if config.Transport.MaxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = config.Transport.MaxIdleConnsPerHost
+		}
+		if config.Transport.KeepAlive > 0 {
+			transport.DialContext = (&net.Dialer{KeepAlive: config.Transport.KeepAlive}).DialContext
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	cache, err := newBlobCache(config.CacheSize, config.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("constructing cache: %w", err)
 	}
+
+	return &SunriseDA{
+		config:     config,
+		verifier:   verifier,
+		httpClient: httpClient,
+		auth:       newAuthenticator(config.Auth),
+		cache:      cache,
+	}, nil
+}
+
+// Purge evicts id from the in-process Get cache, if present.
+func (sunrise *SunriseDA) Purge(id da.ID) {
+	sunrise.cache.purge(string(id))
+}
+
+// Stats reports the in-process Get cache's hit rate.
+func (sunrise *SunriseDA) Stats() CacheStats {
+	return sunrise.cache.stats()
 }
 
 var _ da.DA = &SunriseDA{}
@@ -58,154 +184,223 @@ func (sunrise *SunriseDA) MaxBlobSize(ctx context.Context) (uint64, error) {
 }
 
 func (sunrise *SunriseDA) Submit(ctx context.Context, daBlobs []da.Blob, gasPrice float64, namespace da.Namespace) ([]da.ID, error) {
-	resultChan := make(chan PublishResponse, len(daBlobs))
-	errorChan := make(chan error, len(daBlobs))
+	return sunrise.SubmitWithOptions(ctx, daBlobs, gasPrice, namespace, SubmitOptions{})
+}
 
-	var wg sync.WaitGroup
+// SubmitWithOptions is Submit with per-call overrides, such as targeting a
+// storage protocol other than Config.Protocol.
+func (sunrise *SunriseDA) SubmitWithOptions(ctx context.Context, daBlobs []da.Blob, gasPrice float64, namespace da.Namespace, opts SubmitOptions) ([]da.ID, error) {
+	protocol := opts.Protocol
+	if protocol == "" {
+		protocol = sunrise.config.Protocol
+	}
+	if err := validateProtocol(protocol); err != nil {
+		return nil, err
+	}
 
-	var mu sync.Mutex
+	if sunrise.config.SubmitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sunrise.config.SubmitTimeout)
+		defer cancel()
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	ids := make([]da.ID, len(daBlobs))
 
-	for _, blob := range daBlobs {
-		wg.Add(1)
+	for i, blob := range daBlobs {
+		i, blob := i, blob
 
-		// Start a goroutine for each blob
-		go func(blob da.Blob) {
-			defer wg.Done()
+		g.Go(func() error {
 			encodedBlob := base64.StdEncoding.EncodeToString(blob)
 			requestData := PublishRequest{
 				Blob:             encodedBlob,
 				DataShardCount:   int(sunrise.config.DataShardCount),
 				ParityShardCount: int(sunrise.config.ParityShardCount),
-				Protocol:         "ipfs",
+				Protocol:         protocol,
 			}
 
 			requestBody, err := json.Marshal(requestData)
 			if err != nil {
-				errorChan <- err
-				return
-			}
-
-			response, err := http.Post(sunrise.config.ServerURL+"/api/publish", "application/json", bytes.NewBuffer(requestBody))
-			if err != nil {
-				errorChan <- err
-				return
+				return err
 			}
 
-			defer func() {
-				err = response.Body.Close()
-				if err != nil {
-					log.Println("error closing response body", err)
-				}
-			}()
-
-			responseData, err := io.ReadAll(response.Body)
+			responseData, _, err := sunrise.doRequestWithRetry(ctx, http.MethodPost, sunrise.config.ServerURL+"/api/publish", requestBody, "application/json")
 			if err != nil {
-				errorChan <- err
-				return
+				return err
 			}
 
 			var publishResponse PublishResponse
-			err = json.Unmarshal(responseData, &publishResponse)
-			if err != nil {
-				errorChan <- err
-				return
+			if err := json.Unmarshal(responseData, &publishResponse); err != nil {
+				return err
 			}
 
-			// Acquire the mutex before updating slices
-			mu.Lock()
-			resultChan <- PublishResponse{
-				TxHash:      publishResponse.TxHash,
-				MetadataUri: publishResponse.MetadataUri,
-			}
-			mu.Unlock()
-
-		}(blob)
-	}
-
-	go func() {
-		wg.Wait()
-		close(resultChan)
-		close(errorChan)
-	}()
-
-	// Collect results from channels
-	var ids []da.ID
-
-	for result := range resultChan {
-		ids = append(ids, []byte(result.MetadataUri))
+			ids[i] = []byte(publishResponse.MetadataUri)
+			return nil
+		})
 	}
 
-	// Check for errors
-	if err := <-errorChan; err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+
+	return ids, nil
 }
 
 func (sunrise *SunriseDA) Get(ctx context.Context, ids []da.ID, namespace da.Namespace) ([]da.Blob, error) {
-	var blobs [][]byte
-	var metadataUri string
-	for _, id := range ids {
-		metadataUri = string(id)
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/get-blob?metadata_uri=%s", sunrise.config.ServerURL, metadataUri), nil)
-		if err != nil {
-			return nil, err
+	if sunrise.config.GetTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sunrise.config.GetTimeout)
+		defer cancel()
+	}
+
+	blobs := make([]da.Blob, len(ids))
+	for i, id := range ids {
+		metadataUri := string(id)
+
+		if blob, ok := sunrise.cache.get(metadataUri); ok {
+			blobs[i] = blob
+			continue
 		}
-		client := http.DefaultClient
-		response, err := client.Do(req)
+
+		url := fmt.Sprintf("%s/api/get-blob?metadata_uri=%s", sunrise.config.ServerURL, metadataUri)
+
+		responseData, header, err := sunrise.doRequestWithRetry(ctx, http.MethodGet, url, nil, "")
 		if err != nil {
 			return nil, err
 		}
-		defer func() {
-			err = response.Body.Close()
-			if err != nil {
-				log.Println("error closing response body", err)
-			}
-		}()
-		responseData, err := io.ReadAll(response.Body)
-		if err != nil {
+
+		var blobResponse GetBlobResponse
+		if err := json.Unmarshal(responseData, &blobResponse); err != nil {
 			return nil, err
 		}
-		var blobResponse GetBlobResponse
 
-		err = json.Unmarshal(responseData, &blobResponse)
+		decodedBlob, err := base64.StdEncoding.DecodeString(blobResponse.Blob)
 		if err != nil {
 			return nil, err
 		}
 
-		var blob = blobResponse.Blob
-
-		decodedBlob, err := base64.StdEncoding.DecodeString(blob)
-		if err != nil {
-			return nil, err
+		if !cacheControlNoStore(header.Get("Cache-Control")) {
+			sunrise.cache.set(metadataUri, decodedBlob)
 		}
 
-		blobs = append(blobs, decodedBlob)
+		blobs[i] = decodedBlob
 	}
 	return blobs, nil
 }
 
-func (c *SunriseDA) GetIDs(ctx context.Context, height uint64, namespace da.Namespace) ([]da.ID, error) {
-	heightAsUint32 := uint32(height)
-	ids := make([]byte, 8)
-	binary.BigEndian.PutUint32(ids, heightAsUint32)
+// ErrHeightNotFound is returned by GetIDs when the requested height has not
+// produced any blobs in the given namespace yet, so rollkit's block manager
+// can tell "not yet produced" apart from a transport error.
+var ErrHeightNotFound = errors.New("sunrise: no blobs found at height")
+
+type idsResponse struct {
+	IDs []string `json:"ids"`
+}
+
+func (sunrise *SunriseDA) GetIDs(ctx context.Context, height uint64, namespace da.Namespace) ([]da.ID, error) {
+	query := url.Values{}
+	query.Set("height", strconv.FormatUint(height, 10))
+	query.Set("namespace", string(namespace))
+	requestURL := fmt.Sprintf("%s/api/ids?%s", sunrise.config.ServerURL, query.Encode())
+
+	responseData, _, err := sunrise.doRequestWithRetry(ctx, http.MethodGet, requestURL, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching ids at height %d: %w", height, err)
+	}
+
+	var response idsResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("decoding ids response at height %d: %w", height, err)
+	}
+
+	if len(response.IDs) == 0 {
+		return nil, ErrHeightNotFound
+	}
+
+	ids := make([]da.ID, len(response.IDs))
+	for i, metadataUri := range response.IDs {
+		ids[i] = da.ID(metadataUri)
+	}
 
-	return [][]byte{ids}, nil
+	return ids, nil
 }
 
 func (sunrise *SunriseDA) GetProofs(ctx context.Context, ids []da.ID, namespace da.Namespace) ([]da.Proof, error) {
-	var proofs []da.Proof
+	proofs := make([]da.Proof, len(ids))
+
+	for i, id := range ids {
+		url := fmt.Sprintf("%s/api/data-proof?metadata_uri=%s", sunrise.config.ServerURL, string(id))
+
+		responseData, _, err := sunrise.doRequestWithRetry(ctx, http.MethodGet, url, nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("fetching proof for id %q: %w", id, err)
+		}
+
+		var proof verify.DataProof
+		if err := json.Unmarshal(responseData, &proof); err != nil {
+			return nil, fmt.Errorf("decoding proof for id %q: %w", id, err)
+		}
+
+		encoded, err := json.Marshal(proof)
+		if err != nil {
+			return nil, fmt.Errorf("encoding proof for id %q: %w", id, err)
+		}
+
+		proofs[i] = encoded
+	}
 
 	return proofs, nil
 }
 
 func (sunrise *SunriseDA) Commit(ctx context.Context, daBlobs []da.Blob, namespace da.Namespace) ([]da.Commitment, error) {
-	var commitments []da.Commitment
+	blobs := make([][]byte, len(daBlobs))
+	for i, blob := range daBlobs {
+		blobs[i] = blob
+	}
+
+	commitments, err := verify.Commit(blobs, sunrise.config.DataShardCount, sunrise.config.ParityShardCount)
+	if err != nil {
+		return nil, fmt.Errorf("computing commitments: %w", err)
+	}
 
-	return commitments, nil
+	result := make([]da.Commitment, len(commitments))
+	for i, commitment := range commitments {
+		result[i] = commitment
+	}
+
+	return result, nil
 }
 
 func (sunrise *SunriseDA) Validate(ctx context.Context, ids []da.ID, daProofs []da.Proof, namespace da.Namespace) ([]bool, error) {
-	var valid []bool
+	if len(ids) != len(daProofs) {
+		return nil, fmt.Errorf("validate: got %d ids but %d proofs", len(ids), len(daProofs))
+	}
+
+	valid := make([]bool, len(ids))
+
+	for i, id := range ids {
+		blobs, err := sunrise.Get(ctx, []da.ID{id}, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving blob for id %q: %w", id, err)
+		}
+
+		commitments, err := verify.Commit([][]byte{blobs[0]}, sunrise.config.DataShardCount, sunrise.config.ParityShardCount)
+		if err != nil {
+			return nil, fmt.Errorf("recomputing commitment for id %q: %w", id, err)
+		}
+
+		var proof verify.DataProof
+		if err := json.Unmarshal(daProofs[i], &proof); err != nil {
+			return nil, fmt.Errorf("decoding proof for id %q: %w", id, err)
+		}
+
+		ok, err := sunrise.verifier.Validate(ctx, commitments[0], &proof)
+		if err != nil {
+			return nil, fmt.Errorf("validating proof for id %q: %w", id, err)
+		}
+
+		valid[i] = ok
+	}
 
 	return valid, nil
 }
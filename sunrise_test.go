@@ -0,0 +1,39 @@
+package sunrise
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateProtocol(t *testing.T) {
+	cases := []struct {
+		name     string
+		protocol string
+		wantErr  bool
+	}{
+		{"ipfs", ProtocolIPFS, false},
+		{"arweave", ProtocolArweave, false},
+		{"filecoin", ProtocolFilecoin, false},
+		{"local", ProtocolLocal, false},
+		{"unknown", "bogus", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateProtocol(tc.protocol)
+
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected error for protocol %q", tc.protocol)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error for protocol %q: %v", tc.protocol, err)
+			}
+
+			var unsupported *ErrUnsupportedProtocol
+			if tc.wantErr && !errors.As(err, &unsupported) {
+				t.Fatalf("expected *ErrUnsupportedProtocol, got %T", err)
+			}
+		})
+	}
+}
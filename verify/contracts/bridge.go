@@ -0,0 +1,51 @@
+// Package contracts holds bridge-contract bindings consumed by the verify
+// package. bridge.go is a hand-maintained stand-in for the abigen output a
+// real deployment would generate with:
+//
+//	abigen --abi bridge.abi --pkg contracts --type BridgeContract --out bridge.go
+//
+// Regenerate it once the production bridge contract's ABI is finalized.
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const bridgeContractABI = `[{"inputs":[{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes32[]","name":"proof","type":"bytes32[]"},{"internalType":"uint256","name":"proofIndex","type":"uint256"},{"internalType":"uint256","name":"numLeaves","type":"uint256"}],"name":"verifyProof","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"}]`
+
+// BridgeContract is a thin binding over the on-chain data-root bridge that
+// sunrise rollups use to check inclusion proofs returned by a sunrise node.
+type BridgeContract struct {
+	contract *bind.BoundContract
+}
+
+// NewBridgeContract binds a BridgeContract to an already-deployed contract
+// at address, using backend for calls.
+func NewBridgeContract(address common.Address, backend bind.ContractBackend) (*BridgeContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(bridgeContractABI))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BridgeContract{
+		contract: bind.NewBoundContract(address, parsed, backend, backend, backend),
+	}, nil
+}
+
+// VerifyProof calls the bridge contract's verifyProof view function,
+// checking that proof attests to dataRoot's inclusion at proofIndex among
+// numLeaves leaves of the bridged state root.
+func (b *BridgeContract) VerifyProof(opts *bind.CallOpts, dataRoot [32]byte, proof [][32]byte, proofIndex, numLeaves uint64) (bool, error) {
+	var out []interface{}
+	err := b.contract.Call(opts, &out, "verifyProof", dataRoot, proof, new(big.Int).SetUint64(proofIndex), new(big.Int).SetUint64(numLeaves))
+	if err != nil {
+		return false, err
+	}
+
+	return out[0].(bool), nil
+}
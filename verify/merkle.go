@@ -0,0 +1,36 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// merkleRoot computes a simple binary Merkle root over leaves, hashing each
+// leaf with sha256 and promoting an unpaired leaf at each level rather than
+// duplicating it.
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		h := sha256.Sum256(leaf)
+		level[i] = h[:]
+	}
+
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			h := sha256.Sum256(append(bytes.Clone(level[i]), level[i+1]...))
+			next = append(next, h[:])
+		}
+		level = next
+	}
+
+	return level[0]
+}
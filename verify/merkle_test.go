@@ -0,0 +1,49 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestMerkleRootEmpty(t *testing.T) {
+	if got := merkleRoot(nil); got != nil {
+		t.Fatalf("expected nil root for no leaves, got %x", got)
+	}
+}
+
+func TestMerkleRootSingleLeaf(t *testing.T) {
+	leaf := []byte("leaf")
+	want := sha256.Sum256(leaf)
+
+	got := merkleRoot([][]byte{leaf})
+	if !bytes.Equal(got, want[:]) {
+		t.Fatalf("got %x want %x", got, want)
+	}
+}
+
+func TestMerkleRootDeterministic(t *testing.T) {
+	leaves := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+
+	first := merkleRoot(leaves)
+	second := merkleRoot(leaves)
+	if !bytes.Equal(first, second) {
+		t.Fatalf("merkleRoot(%v) is not deterministic: %x != %x", leaves, first, second)
+	}
+}
+
+func TestMerkleRootDiffersOnOrder(t *testing.T) {
+	a := merkleRoot([][]byte{[]byte("a"), []byte("b")})
+	b := merkleRoot([][]byte{[]byte("b"), []byte("a")})
+	if bytes.Equal(a, b) {
+		t.Fatalf("expected different roots for different leaf order, both got %x", a)
+	}
+}
+
+func TestMerkleRootOddLeafCount(t *testing.T) {
+	// Exercises the unpaired-leaf-promotion branch.
+	leaves := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	if got := merkleRoot(leaves); len(got) != sha256.Size {
+		t.Fatalf("expected a %d-byte root, got %d bytes", sha256.Size, len(got))
+	}
+}
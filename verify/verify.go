@@ -0,0 +1,116 @@
+// Package verify computes blob commitments and checks on-chain inclusion
+// proofs for the sunrise DA layer, mirroring the DAVerifier / bridge-contract
+// binding pattern go-daash uses for its Avail and Celestia backends.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/klauspost/reedsolomon"
+
+	"github.com/sunriselayer/sunrise-rollkit/verify/contracts"
+)
+
+// Config holds the on-chain verifier settings for a SunriseDA instance.
+type Config struct {
+	// BridgeContractAddress is the address of the deployed data-root bridge
+	// contract on the target EVM chain. Validate returns an error if this
+	// is unset.
+	BridgeContractAddress string `json:"bridge_contract_address"`
+	// EthereumRPCURL is the JSON-RPC endpoint used to reach the bridge
+	// chain.
+	EthereumRPCURL string `json:"ethereum_rpc_url"`
+}
+
+// DataProof is the inclusion proof returned by a sunrise node's
+// /api/data-proof endpoint, relating a blob's data root to the state root
+// checked on-chain by the bridge contract.
+type DataProof struct {
+	DataRoot   []byte   `json:"data_root"`
+	Proof      [][]byte `json:"proof"`
+	ProofIndex uint64   `json:"proof_index"`
+	NumLeaves  uint64   `json:"num_leaves"`
+}
+
+// Verifier checks inclusion proofs against a configured bridge contract.
+// Commitments are computed by the package-level Commit function.
+type Verifier struct {
+	config Config
+	bridge *contracts.BridgeContract
+}
+
+// NewVerifier binds the bridge contract configured in config. A Config
+// missing either field yields a Verifier whose Validate always errors,
+// since on-chain validation has nowhere to check against.
+func NewVerifier(config Config) (*Verifier, error) {
+	if config.EthereumRPCURL == "" || config.BridgeContractAddress == "" {
+		return &Verifier{config: config}, nil
+	}
+
+	client, err := ethclient.Dial(config.EthereumRPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("verify: dialing ethereum rpc: %w", err)
+	}
+
+	bridge, err := contracts.NewBridgeContract(common.HexToAddress(config.BridgeContractAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("verify: binding bridge contract: %w", err)
+	}
+
+	return &Verifier{config: config, bridge: bridge}, nil
+}
+
+// Commit Reed-Solomon encodes each blob into dataShards data shards and
+// parityShards parity shards, then returns the Merkle root over the
+// resulting shard set as that blob's commitment.
+func Commit(daBlobs [][]byte, dataShards, parityShards uint32) ([][]byte, error) {
+	if dataShards == 0 {
+		dataShards = 1
+	}
+
+	enc, err := reedsolomon.New(int(dataShards), int(parityShards))
+	if err != nil {
+		return nil, fmt.Errorf("verify: creating reed-solomon encoder: %w", err)
+	}
+
+	commitments := make([][]byte, len(daBlobs))
+	for i, blob := range daBlobs {
+		shards, err := enc.Split(blob)
+		if err != nil {
+			return nil, fmt.Errorf("verify: splitting blob %d into shards: %w", i, err)
+		}
+		if err := enc.Encode(shards); err != nil {
+			return nil, fmt.Errorf("verify: encoding parity shards for blob %d: %w", i, err)
+		}
+		commitments[i] = merkleRoot(shards)
+	}
+
+	return commitments, nil
+}
+
+// Validate reports whether commitment equals proof's data root and the
+// bridge contract's verifyProof accepts proof for that root. The caller
+// supplies commitment; Validate does not recompute it.
+func (v *Verifier) Validate(ctx context.Context, commitment []byte, proof *DataProof) (bool, error) {
+	if v.bridge == nil {
+		return false, fmt.Errorf("verify: no bridge contract configured")
+	}
+	if !bytes.Equal(commitment, proof.DataRoot) {
+		return false, nil
+	}
+
+	var root [32]byte
+	copy(root[:], proof.DataRoot)
+
+	proofNodes := make([][32]byte, len(proof.Proof))
+	for i, node := range proof.Proof {
+		copy(proofNodes[i][:], node)
+	}
+
+	return v.bridge.VerifyProof(&bind.CallOpts{Context: ctx}, root, proofNodes, proof.ProofIndex, proof.NumLeaves)
+}
@@ -0,0 +1,40 @@
+package verify
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCommit(t *testing.T) {
+	blobs := [][]byte{
+		bytes.Repeat([]byte{1}, 64),
+		bytes.Repeat([]byte{2}, 64),
+	}
+
+	commitments, err := Commit(blobs, 2, 1)
+	if err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+	if len(commitments) != len(blobs) {
+		t.Fatalf("expected %d commitments, got %d", len(blobs), len(commitments))
+	}
+	if bytes.Equal(commitments[0], commitments[1]) {
+		t.Fatalf("expected distinct commitments for distinct blobs")
+	}
+
+	again, err := Commit(blobs, 2, 1)
+	if err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+	if !bytes.Equal(commitments[0], again[0]) {
+		t.Fatalf("Commit is not deterministic for the same blob and shard counts")
+	}
+}
+
+func TestCommitDefaultsDataShards(t *testing.T) {
+	blobs := [][]byte{bytes.Repeat([]byte{7}, 32)}
+
+	if _, err := Commit(blobs, 0, 1); err != nil {
+		t.Fatalf("Commit with zero dataShards should default to 1, got error: %v", err)
+	}
+}